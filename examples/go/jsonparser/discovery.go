@@ -0,0 +1,42 @@
+// jsonparser/discovery.go
+package jsonparser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FindConfigFile は name という名前の設定ファイルを既定の探索順
+// （カレントディレクトリ、./.github/workflows/、../.github/workflows/、
+// および JSON2VARS_CONFIG_PATH 環境変数に列挙されたディレクトリ）で探し、
+// 最初に見つかった絶対パスを返します。JSON2VARS_CONFIG_PATH は
+// os.PathListSeparator（Unixでは ':'）区切りで複数ディレクトリを指定できます。
+func FindConfigFile(name string) (string, error) {
+	dirs := []string{
+		".",
+		filepath.Join(".", ".github", "workflows"),
+		filepath.Join("..", ".github", "workflows"),
+	}
+
+	if envPaths := os.Getenv("JSON2VARS_CONFIG_PATH"); envPaths != "" {
+		dirs = append(dirs, strings.Split(envPaths, string(os.PathListSeparator))...)
+	}
+
+	for _, dir := range dirs {
+		candidate := filepath.Join(dir, name)
+		info, err := os.Stat(candidate)
+		if err != nil || info.IsDir() {
+			continue
+		}
+
+		abs, err := filepath.Abs(candidate)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve absolute path for %s: %w", candidate, err)
+		}
+		return abs, nil
+	}
+
+	return "", fmt.Errorf("config file %q not found in any of the searched directories", name)
+}
@@ -0,0 +1,39 @@
+// jsonparser/discovery_test.go
+package jsonparser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "go_project_matrix.json")
+	if err := os.WriteFile(configPath, []byte(`{}`), 0644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	t.Setenv("JSON2VARS_CONFIG_PATH", dir)
+
+	got, err := FindConfigFile("go_project_matrix.json")
+	if err != nil {
+		t.Fatalf("FindConfigFile() unexpected error = %v", err)
+	}
+
+	want, err := filepath.Abs(configPath)
+	if err != nil {
+		t.Fatalf("failed to resolve want path: %v", err)
+	}
+	if got != want {
+		t.Errorf("FindConfigFile() = %q, want %q", got, want)
+	}
+}
+
+func TestFindConfigFileNotFound(t *testing.T) {
+	t.Setenv("JSON2VARS_CONFIG_PATH", t.TempDir())
+
+	if _, err := FindConfigFile("does-not-exist.json"); err == nil {
+		t.Error("FindConfigFile() expected an error for a missing file, got nil")
+	}
+}
@@ -0,0 +1,57 @@
+// jsonparser/languages.go
+package jsonparser
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+var (
+	languageValidatorsMu sync.Mutex
+	languageValidators   = map[string]func([]string) error{}
+)
+
+// RegisterLanguage は lang のバージョン一覧を検証する validator を登録します。
+// 登録済みの validator は ValidateLanguages が呼び出し時に使用します。同じ
+// lang で再登録した場合は既存の validator を置き換えます。
+func RegisterLanguage(lang string, validator func([]string) error) {
+	languageValidatorsMu.Lock()
+	defer languageValidatorsMu.Unlock()
+	languageValidators[lang] = validator
+}
+
+// Languages は Versions に含まれる言語キーをソート済みで返します。
+func (c *MatrixConfig) Languages() []string {
+	langs := make([]string, 0, len(c.Versions))
+	for lang := range c.Versions {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// VersionsFor は指定した言語のバージョン一覧を返します。該当する言語が
+// なければ nil を返します。
+func (c *MatrixConfig) VersionsFor(lang string) []string {
+	return c.Versions[lang]
+}
+
+// ValidateLanguages は Versions の各言語について、RegisterLanguage で
+// validator が登録されていればそれを実行します。validator が未登録の言語は
+// 検証をスキップします。
+func (c *MatrixConfig) ValidateLanguages() error {
+	languageValidatorsMu.Lock()
+	defer languageValidatorsMu.Unlock()
+
+	for _, lang := range c.Languages() {
+		validator, ok := languageValidators[lang]
+		if !ok {
+			continue
+		}
+		if err := validator(c.Versions[lang]); err != nil {
+			return fmt.Errorf("versions.%s: %w", lang, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+// jsonparser/languages_test.go
+package jsonparser
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+const multiLanguageFixture = `{
+	"os": ["ubuntu-latest"],
+	"versions": {
+		"go": ["1.23.0", "1.23.1"],
+		"python": ["3.11.0", "3.12.1"],
+		"node": ["v18.20.0", "v20.11.0"]
+	},
+	"ghpages_branch": "gh-pages",
+	"include": [{"os": "macos-latest", "go": "1.23.1"}],
+	"env": {"CGO_ENABLED": "0"}
+}`
+
+func TestMatrixConfigExtras(t *testing.T) {
+	var config MatrixConfig
+	if err := json.Unmarshal([]byte(multiLanguageFixture), &config); err != nil {
+		t.Fatalf("failed to unmarshal fixture: %v", err)
+	}
+
+	if _, ok := config.Extras["include"]; !ok {
+		t.Error("Expected Extras to retain the 'include' key")
+	}
+	if _, ok := config.Extras["env"]; !ok {
+		t.Error("Expected Extras to retain the 'env' key")
+	}
+	if _, ok := config.Extras["os"]; ok {
+		t.Error("Expected Extras to not retain the known 'os' key")
+	}
+
+	remarshaled, err := json.Marshal(&config)
+	if err != nil {
+		t.Fatalf("failed to marshal config: %v", err)
+	}
+
+	var roundTripped MatrixConfig
+	if err := json.Unmarshal(remarshaled, &roundTripped); err != nil {
+		t.Fatalf("failed to unmarshal round-tripped config: %v", err)
+	}
+	if len(roundTripped.Extras) != len(config.Extras) {
+		t.Fatalf("Extras did not survive a marshal/unmarshal round trip: got %v, want %v", roundTripped.Extras, config.Extras)
+	}
+	for key, want := range config.Extras {
+		got, ok := roundTripped.Extras[key]
+		if !ok {
+			t.Errorf("Extras[%q] missing after round trip", key)
+			continue
+		}
+		var wantValue, gotValue interface{}
+		if err := json.Unmarshal(want, &wantValue); err != nil {
+			t.Fatalf("failed to decode want value for %q: %v", key, err)
+		}
+		if err := json.Unmarshal(got, &gotValue); err != nil {
+			t.Fatalf("failed to decode got value for %q: %v", key, err)
+		}
+		if !reflect.DeepEqual(gotValue, wantValue) {
+			t.Errorf("Extras[%q] = %v, want %v", key, gotValue, wantValue)
+		}
+	}
+}
+
+func TestMatrixConfigLanguageAccessors(t *testing.T) {
+	var config MatrixConfig
+	if err := json.Unmarshal([]byte(multiLanguageFixture), &config); err != nil {
+		t.Fatalf("failed to unmarshal fixture: %v", err)
+	}
+
+	wantLangs := []string{"go", "node", "python"}
+	if got := config.Languages(); !reflect.DeepEqual(got, wantLangs) {
+		t.Errorf("Languages() = %v, want %v", got, wantLangs)
+	}
+
+	if got := config.VersionsFor("python"); !reflect.DeepEqual(got, []string{"3.11.0", "3.12.1"}) {
+		t.Errorf("VersionsFor(\"python\") = %v, want [3.11.0 3.12.1]", got)
+	}
+	if got := config.VersionsFor("rust"); got != nil {
+		t.Errorf("VersionsFor(\"rust\") = %v, want nil", got)
+	}
+}
+
+func TestRegisterLanguageValidation(t *testing.T) {
+	RegisterLanguage("go", SemverValidator)
+	RegisterLanguage("python", PEP440Validator)
+	RegisterLanguage("node", NodeSemverValidator)
+	defer func() {
+		languageValidatorsMu.Lock()
+		delete(languageValidators, "go")
+		delete(languageValidators, "python")
+		delete(languageValidators, "node")
+		languageValidatorsMu.Unlock()
+	}()
+
+	var config MatrixConfig
+	if err := json.Unmarshal([]byte(multiLanguageFixture), &config); err != nil {
+		t.Fatalf("failed to unmarshal fixture: %v", err)
+	}
+
+	if err := config.ValidateLanguages(); err != nil {
+		t.Errorf("ValidateLanguages() unexpected error = %v", err)
+	}
+
+	config.Versions["node"] = []string{"20.11.0"} // missing required "v" prefix
+	if err := config.ValidateLanguages(); err == nil {
+		t.Error("ValidateLanguages() expected an error for a malformed node version, got nil")
+	}
+}
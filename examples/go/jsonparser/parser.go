@@ -5,17 +5,97 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+
+	"json2vars-setter-example/jsonparser/schema"
 )
 
-// MatrixConfig は設定JSONの構造を定義します
+// MatrixConfig は設定JSONの構造を定義します。os, versions, ghpages_branch
+// 以外のトップレベルキー（include/exclude ブロックや env など）は Extras に
+// 未解釈のまま保持されるため、カスタムUnmarshalJSON/MarshalJSONを通じて
+// 情報を落とさずに読み書きできます。
 type MatrixConfig struct {
-	OS            []string            `json:"os"`
-	Versions      map[string][]string `json:"versions"`
-	GhPagesBranch string              `json:"ghpages_branch"`
+	OS            []string                   `json:"os"`
+	Versions      map[string][]string        `json:"versions"`
+	GhPagesBranch string                     `json:"ghpages_branch"`
+	Extras        map[string]json.RawMessage `json:"-"`
+}
+
+// matrixConfigAlias は UnmarshalJSON/MarshalJSON から再帰呼び出しを避けて
+// 既知フィールドだけをエンコード/デコードするためのエイリアス型です。
+type matrixConfigAlias MatrixConfig
+
+// UnmarshalJSON は os, versions, ghpages_branch を対応するフィールドに
+// デコードしつつ、それ以外のトップレベルキーを Extras に保持します。
+func (c *MatrixConfig) UnmarshalJSON(data []byte) error {
+	var known matrixConfigAlias
+	if err := json.Unmarshal(data, &known); err != nil {
+		return err
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	delete(raw, "os")
+	delete(raw, "versions")
+	delete(raw, "ghpages_branch")
+
+	*c = MatrixConfig(known)
+	if len(raw) > 0 {
+		c.Extras = raw
+	} else {
+		c.Extras = nil
+	}
+	return nil
+}
+
+// MarshalJSON は既知のフィールドと Extras を1つのJSONオブジェクトに
+// マージして書き出します。
+func (c MatrixConfig) MarshalJSON() ([]byte, error) {
+	out := make(map[string]json.RawMessage, len(c.Extras)+3)
+	for k, v := range c.Extras {
+		out[k] = v
+	}
+
+	osBytes, err := json.Marshal(c.OS)
+	if err != nil {
+		return nil, err
+	}
+	out["os"] = osBytes
+
+	versionsBytes, err := json.Marshal(c.Versions)
+	if err != nil {
+		return nil, err
+	}
+	out["versions"] = versionsBytes
+
+	ghBytes, err := json.Marshal(c.GhPagesBranch)
+	if err != nil {
+		return nil, err
+	}
+	out["ghpages_branch"] = ghBytes
+
+	return json.Marshal(out)
+}
+
+// ParseConfigOptions は ParseConfigWithOptions の挙動を調整します
+type ParseConfigOptions struct {
+	// AllowComments が true の場合、 // および /* */ 形式のコメントと
+	// 末尾のカンマを読み飛ばしてから JSON をデコードします。false の場合は
+	// encoding/json による厳密なパースのみを行います。
+	AllowComments bool
 }
 
 // ParseConfig はJSONファイルを読み込んで解析します
+// インラインコメント（// や /* */）や末尾カンマを含むファイルも許容します。
+// 厳密なJSONのみを受け付けたい場合は ParseConfigWithOptions を使用してください。
 func ParseConfig(filePath string, silent bool) (*MatrixConfig, error) {
+	return ParseConfigWithOptions(filePath, silent, ParseConfigOptions{AllowComments: true})
+}
+
+// ParseConfigWithOptions はJSONファイルを読み込んで解析します。opts で
+// コメントや末尾カンマの許容有無を指定できます。
+func ParseConfigWithOptions(filePath string, silent bool, opts ParseConfigOptions) (*MatrixConfig, error) {
 	data, err := os.ReadFile(filePath)
 	if err != nil {
 		if !silent {
@@ -24,6 +104,10 @@ func ParseConfig(filePath string, silent bool) (*MatrixConfig, error) {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
+	if opts.AllowComments {
+		data = stripJSONComments(data)
+	}
+
 	var config MatrixConfig
 	if err := json.Unmarshal(data, &config); err != nil {
 		if !silent {
@@ -34,3 +118,150 @@ func ParseConfig(filePath string, silent bool) (*MatrixConfig, error) {
 
 	return &config, nil
 }
+
+// ParseConfigWithSchema はJSONファイルを読み込み、schemaData に渡した
+// JSON Schema で検証してから MatrixConfig にデコードします。検証に失敗した
+// 場合は schema.ValidationErrors を返すので、呼び出し側はどのフィールドが
+// どう不正なのか（JSON Pointerパス付き）を利用者に提示できます。
+// Goプロジェクト以外のマトリクスを扱う場合は独自のschemaDataを渡すことで
+// バリデーションルールを差し替えられます。
+func ParseConfigWithSchema(filePath string, schemaData []byte) (*MatrixConfig, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	data = stripJSONComments(data)
+
+	violations, err := schema.Validate(data, schemaData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate config: %w", err)
+	}
+	if len(violations) > 0 {
+		return nil, fmt.Errorf("config failed schema validation: %w", violations)
+	}
+
+	var config MatrixConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+
+	return &config, nil
+}
+
+// ParseConfigRaw はJSONファイルを読み込み、コメントと末尾カンマを取り除いた
+// 上で汎用的なJSON値（map[string]interface{} など）としてデコードします。
+// MatrixConfig が定義していないフィールド（include/excludeブロックなど）も
+// 保持したままJSON Pointerで編集したい場合に使用します。
+func ParseConfigRaw(filePath string) (interface{}, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	data = stripJSONComments(data)
+
+	var raw interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+
+	return raw, nil
+}
+
+// stripJSONComments は data から // 行コメント、 /* */ ブロックコメント、
+// および配列・オブジェクトの末尾カンマを取り除きます。文字列リテラルの
+// 内側にあるこれらの記号は対象にしません。
+// github.com/DisposaBoy/JsonConfigReader のストリッピング方式を参考にした
+// 簡易実装です。
+func stripJSONComments(data []byte) []byte {
+	out := make([]byte, 0, len(data))
+
+	inString := false
+	inLineComment := false
+	inBlockComment := false
+
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		var next byte
+		if i+1 < len(data) {
+			next = data[i+1]
+		}
+
+		if inLineComment {
+			if c == '\n' {
+				inLineComment = false
+				out = append(out, c)
+			}
+			continue
+		}
+
+		if inBlockComment {
+			if c == '*' && next == '/' {
+				inBlockComment = false
+				i++
+			}
+			continue
+		}
+
+		if inString {
+			out = append(out, c)
+			if c == '\\' && i+1 < len(data) {
+				out = append(out, next)
+				i++
+				continue
+			}
+			if c == '"' {
+				inString = false
+			}
+			continue
+		}
+
+		switch {
+		case c == '"':
+			inString = true
+			out = append(out, c)
+		case c == '/' && next == '/':
+			inLineComment = true
+			i++
+		case c == '/' && next == '*':
+			inBlockComment = true
+			i++
+		case c == ',':
+			if j := nextSignificantByte(data, i+1); j == '}' || j == ']' {
+				// 末尾カンマは出力しない
+				continue
+			}
+			out = append(out, c)
+		default:
+			out = append(out, c)
+		}
+	}
+
+	return out
+}
+
+// nextSignificantByte は pos 以降にある、空白・改行・コメント以外の最初の
+// バイトを返します。見つからない場合は 0 を返します。
+func nextSignificantByte(data []byte, pos int) byte {
+	for pos < len(data) {
+		c := data[pos]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			pos++
+		case c == '/' && pos+1 < len(data) && data[pos+1] == '/':
+			for pos < len(data) && data[pos] != '\n' {
+				pos++
+			}
+		case c == '/' && pos+1 < len(data) && data[pos+1] == '*':
+			pos += 2
+			for pos+1 < len(data) && !(data[pos] == '*' && data[pos+1] == '/') {
+				pos++
+			}
+			pos += 2
+		default:
+			return c
+		}
+	}
+	return 0
+}
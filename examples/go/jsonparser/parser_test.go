@@ -2,20 +2,28 @@
 package jsonparser
 
 import (
+	"os"
 	"path/filepath"
 	"reflect"
 	"runtime"
+	"strings"
 	"testing"
+
+	"json2vars-setter-example/jsonparser/schema"
 )
 
+const validConfigFixture = `{
+  "os": ["ubuntu-latest", "windows-latest", "macos-latest"],
+  "versions": {"go": ["1.23.0", "1.23.1", "1.23.2"]},
+  "ghpages_branch": "ghgapes"
+}`
+
 func TestParseConfig(t *testing.T) {
-	_, filename, _, ok := runtime.Caller(0)
-	if !ok {
-		t.Fatal("Error getting current file path")
+	configPath := filepath.Join(t.TempDir(), "go_project_matrix.json")
+	if err := os.WriteFile(configPath, []byte(validConfigFixture), 0644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
 	}
 
-	configPath := filepath.Join(filepath.Dir(filename), "../../../.github/workflows/go_project_matrix.json")
-
 	tests := []struct {
 		name    string
 		path    string
@@ -70,3 +78,66 @@ func TestParseConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestParseConfigWithComments(t *testing.T) {
+	_, filename, _, ok := runtime.Caller(0)
+	if !ok {
+		t.Fatal("Error getting current file path")
+	}
+
+	commentedPath := filepath.Join(filepath.Dir(filename), "testdata/go_project_matrix_comments.json")
+
+	expectedOS := []string{"ubuntu-latest", "windows-latest", "macos-latest"}
+	expectedVersions := []string{"1.23.0", "1.23.1", "1.23.2"}
+
+	t.Run("ParseConfig allows comments and trailing commas by default", func(t *testing.T) {
+		config, err := ParseConfig(commentedPath, false)
+		if err != nil {
+			t.Fatalf("ParseConfig() unexpected error = %v", err)
+		}
+		if !reflect.DeepEqual(config.OS, expectedOS) {
+			t.Errorf("Expected OS %v, got %v", expectedOS, config.OS)
+		}
+		if versions, ok := config.Versions["go"]; !ok || !reflect.DeepEqual(versions, expectedVersions) {
+			t.Errorf("Expected versions %v, got %v", expectedVersions, versions)
+		}
+		if config.GhPagesBranch != "ghgapes" {
+			t.Errorf("Expected ghpages_branch 'ghgapes', got %v", config.GhPagesBranch)
+		}
+	})
+
+	t.Run("strict mode rejects comments", func(t *testing.T) {
+		_, err := ParseConfigWithOptions(commentedPath, true, ParseConfigOptions{AllowComments: false})
+		if err == nil {
+			t.Error("ParseConfigWithOptions() expected error in strict mode, got nil")
+		}
+	})
+}
+
+func TestParseConfigWithSchema(t *testing.T) {
+	_, filename, _, ok := runtime.Caller(0)
+	if !ok {
+		t.Fatal("Error getting current file path")
+	}
+	dir := filepath.Dir(filename)
+
+	t.Run("valid config passes schema validation", func(t *testing.T) {
+		config, err := ParseConfigWithSchema(filepath.Join(dir, "testdata/go_project_matrix_comments.json"), schema.MatrixConfigSchema)
+		if err != nil {
+			t.Fatalf("ParseConfigWithSchema() unexpected error = %v", err)
+		}
+		if config.GhPagesBranch != "ghgapes" {
+			t.Errorf("Expected ghpages_branch 'ghgapes', got %v", config.GhPagesBranch)
+		}
+	})
+
+	t.Run("invalid version reports a JSON Pointer path", func(t *testing.T) {
+		_, err := ParseConfigWithSchema(filepath.Join(dir, "testdata/go_project_matrix_invalid.json"), schema.MatrixConfigSchema)
+		if err == nil {
+			t.Fatal("ParseConfigWithSchema() expected a validation error, got nil")
+		}
+		if !strings.Contains(err.Error(), "/versions/go/1") {
+			t.Errorf("Expected error to reference /versions/go/1, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,150 @@
+// schema/schema.go
+package schema
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// MatrixConfigSchema はGoプロジェクト向け MatrixConfig（os, versions,
+// ghpages_branch）を検証するための既定のJSON Schemaです。
+//
+//go:embed matrix_config.schema.json
+var MatrixConfigSchema []byte
+
+// node はこのパッケージが解釈するJSON Schemaのサブセットです。
+// type, required, properties, items, additionalProperties, pattern,
+// minItems, minLength のみをサポートします。
+type node struct {
+	Type                 string           `json:"type"`
+	Required             []string         `json:"required"`
+	Properties           map[string]*node `json:"properties"`
+	Items                *node            `json:"items"`
+	AdditionalProperties *node            `json:"additionalProperties"`
+	Pattern              string           `json:"pattern"`
+	PatternMessage       string           `json:"patternMessage"`
+	MinItems             *int             `json:"minItems"`
+	MinLength            *int             `json:"minLength"`
+}
+
+// ValidationError は、schemaData に違反した値1件を表します。Path は
+// RFC 6901 の JSON Pointer 形式です（例: "/versions/go/2"）。
+type ValidationError struct {
+	Path    string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// ValidationErrors は1回の検証で見つかったすべての ValidationError です。
+type ValidationErrors []*ValidationError
+
+func (errs ValidationErrors) Error() string {
+	msgs := make([]string, len(errs))
+	for i, e := range errs {
+		msgs[i] = e.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Validate は data を汎用的なJSON値としてデコードし、schemaData に記述された
+// JSON Schema に照らして検証します。違反が1件もなければ空の
+// ValidationErrors を返します。schemaData 自体が壊れている、もしくは data
+// がJSONとして不正な場合は error を返します。
+func Validate(data []byte, schemaData []byte) (ValidationErrors, error) {
+	var root node
+	if err := json.Unmarshal(schemaData, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse schema: %w", err)
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+
+	var errs ValidationErrors
+	validateNode(&root, value, "", &errs)
+	return errs, nil
+}
+
+func validateNode(n *node, value interface{}, path string, errs *ValidationErrors) {
+	if n == nil {
+		return
+	}
+
+	switch n.Type {
+	case "object":
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			addError(errs, path, "expected an object")
+			return
+		}
+		for _, name := range n.Required {
+			if _, ok := obj[name]; !ok {
+				addError(errs, joinPointer(path, name), "required property is missing")
+			}
+		}
+		for name, child := range n.Properties {
+			if v, ok := obj[name]; ok {
+				validateNode(child, v, joinPointer(path, name), errs)
+			}
+		}
+		if n.AdditionalProperties != nil {
+			for name, v := range obj {
+				if _, known := n.Properties[name]; known {
+					continue
+				}
+				validateNode(n.AdditionalProperties, v, joinPointer(path, name), errs)
+			}
+		}
+	case "array":
+		arr, ok := value.([]interface{})
+		if !ok {
+			addError(errs, path, "expected an array")
+			return
+		}
+		if n.MinItems != nil && len(arr) < *n.MinItems {
+			addError(errs, path, fmt.Sprintf("expected at least %d item(s), got %d", *n.MinItems, len(arr)))
+		}
+		for i, v := range arr {
+			validateNode(n.Items, v, joinPointer(path, strconv.Itoa(i)), errs)
+		}
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			addError(errs, path, "expected a string")
+			return
+		}
+		if n.MinLength != nil && len(s) < *n.MinLength {
+			addError(errs, path, "must not be empty")
+		}
+		if n.Pattern != "" {
+			matched, err := regexp.MatchString(n.Pattern, s)
+			if err != nil {
+				addError(errs, path, fmt.Sprintf("invalid pattern in schema: %v", err))
+			} else if !matched {
+				msg := n.PatternMessage
+				if msg == "" {
+					msg = "does not match the required pattern"
+				}
+				addError(errs, path, fmt.Sprintf("%q "+msg, s))
+			}
+		}
+	}
+}
+
+func addError(errs *ValidationErrors, path, message string) {
+	*errs = append(*errs, &ValidationError{Path: path, Message: message})
+}
+
+// joinPointer は RFC 6901 に従い、親のJSON Pointerにトークンを1つ追加します。
+func joinPointer(parent, token string) string {
+	token = strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+	return parent + "/" + token
+}
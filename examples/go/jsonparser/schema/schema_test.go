@@ -0,0 +1,81 @@
+// schema/schema_test.go
+package schema
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       string
+		wantErrs   []string
+		wantErrLen int
+	}{
+		{
+			name: "valid config",
+			data: `{
+				"os": ["ubuntu-latest", "windows-latest"],
+				"versions": {"go": ["1.23.0", "1.23.1"]},
+				"ghpages_branch": "gh-pages"
+			}`,
+			wantErrLen: 0,
+		},
+		{
+			name: "missing required os",
+			data: `{
+				"versions": {"go": ["1.23.0"]}
+			}`,
+			wantErrs: []string{"/os: required property is missing"},
+		},
+		{
+			name: "empty os array",
+			data: `{
+				"os": [],
+				"versions": {"go": ["1.23.0"]}
+			}`,
+			wantErrs: []string{"/os: expected at least 1 item(s), got 0"},
+		},
+		{
+			name: "typo'd versions key produces no versions but schema does not know about it",
+			data: `{
+				"os": ["ubuntu-latest"],
+				"verions": {"go": ["1.23.0"]}
+			}`,
+			wantErrs: []string{"/versions: required property is missing"},
+		},
+		{
+			name: "not a valid version",
+			data: `{
+				"os": ["ubuntu-latest"],
+				"versions": {"go": ["1.23.0", "1.23.x"]}
+			}`,
+			wantErrs: []string{`/versions/go/1: "1.23.x" is not a valid version`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs, err := Validate([]byte(tt.data), MatrixConfigSchema)
+			if err != nil {
+				t.Fatalf("Validate() unexpected error = %v", err)
+			}
+			if len(errs) != len(tt.wantErrs) {
+				t.Fatalf("Validate() returned %d errors, want %d: %v", len(errs), len(tt.wantErrs), errs)
+			}
+			for _, want := range tt.wantErrs {
+				found := false
+				for _, e := range errs {
+					if strings.Contains(e.Error(), want) {
+						found = true
+						break
+					}
+				}
+				if !found {
+					t.Errorf("Validate() errors %v do not contain %q", errs, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,542 @@
+// jsonparser/sourceedit.go
+package jsonparser
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	"json2vars-setter-example/jsonpointer"
+)
+
+// sourceKind はソース上のJSON値の種類を表します。
+type sourceKind int
+
+const (
+	sourceScalar sourceKind = iota
+	sourceObject
+	sourceArray
+)
+
+// sourceEntry はオブジェクトの1エントリの、ソース上でのバイト範囲です。
+// commaEnd は値の直後にカンマが続く場合そのカンマの直後位置、続かない
+// 場合は valEnd と同じ値になります。
+type sourceEntry struct {
+	keyStart, keyEnd int
+	valStart, valEnd int
+	commaEnd         int
+	value            *sourceNode
+}
+
+// sourceItem は配列の1要素の、ソース上でのバイト範囲です。
+type sourceItem struct {
+	valStart, valEnd int
+	commaEnd         int
+	value            *sourceNode
+}
+
+// sourceNode はソースファイル中の1つのJSON値を表すノードです。値の種類と
+// バイト範囲だけを記録し、コメント・空白・キー順序はトリビアとして読み
+// 飛ばすのみで一切書き換えないため、編集対象のバイト範囲を特定すること
+// だけに使います。
+type sourceNode struct {
+	kind    sourceKind
+	start   int
+	end     int
+	entries []*sourceEntry
+	items   []*sourceItem
+}
+
+// sourceScanner は stripJSONComments と同じ規則でコメントや空白を読み
+// 飛ばしながら、JSON値のバイト範囲を記録していきます。
+type sourceScanner struct {
+	data []byte
+	pos  int
+}
+
+func parseSourceDoc(data []byte) (*sourceNode, error) {
+	s := &sourceScanner{data: data}
+	s.skipTrivia()
+	return s.parseValue()
+}
+
+func (s *sourceScanner) skipTrivia() {
+	for s.pos < len(s.data) {
+		c := s.data[s.pos]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			s.pos++
+		case c == '/' && s.pos+1 < len(s.data) && s.data[s.pos+1] == '/':
+			for s.pos < len(s.data) && s.data[s.pos] != '\n' {
+				s.pos++
+			}
+		case c == '/' && s.pos+1 < len(s.data) && s.data[s.pos+1] == '*':
+			s.pos += 2
+			for s.pos+1 < len(s.data) && !(s.data[s.pos] == '*' && s.data[s.pos+1] == '/') {
+				s.pos++
+			}
+			s.pos += 2
+		default:
+			return
+		}
+	}
+}
+
+func (s *sourceScanner) parseValue() (*sourceNode, error) {
+	s.skipTrivia()
+	if s.pos >= len(s.data) {
+		return nil, fmt.Errorf("unexpected end of input")
+	}
+	switch s.data[s.pos] {
+	case '{':
+		return s.parseObject()
+	case '[':
+		return s.parseArray()
+	default:
+		return s.parseScalar()
+	}
+}
+
+func (s *sourceScanner) parseObject() (*sourceNode, error) {
+	start := s.pos
+	s.pos++ // consume '{'
+	n := &sourceNode{kind: sourceObject, start: start}
+
+	s.skipTrivia()
+	for s.pos < len(s.data) && s.data[s.pos] != '}' {
+		keyNode, err := s.parseScalar()
+		if err != nil {
+			return nil, fmt.Errorf("expected object key: %w", err)
+		}
+		if s.data[keyNode.start] != '"' {
+			return nil, fmt.Errorf("expected string object key at byte %d", keyNode.start)
+		}
+
+		s.skipTrivia()
+		if s.pos >= len(s.data) || s.data[s.pos] != ':' {
+			return nil, fmt.Errorf("expected ':' after key at byte %d", keyNode.end)
+		}
+		s.pos++
+
+		value, err := s.parseValue()
+		if err != nil {
+			return nil, err
+		}
+
+		entry := &sourceEntry{
+			keyStart: keyNode.start, keyEnd: keyNode.end,
+			valStart: value.start, valEnd: value.end,
+			value: value,
+		}
+		entry.commaEnd = entry.valEnd
+		s.skipTrivia()
+		if s.pos < len(s.data) && s.data[s.pos] == ',' {
+			s.pos++
+			entry.commaEnd = s.pos
+			s.skipTrivia()
+		}
+		n.entries = append(n.entries, entry)
+	}
+	if s.pos >= len(s.data) {
+		return nil, fmt.Errorf("unexpected end of input in object starting at byte %d", start)
+	}
+	s.pos++ // consume '}'
+	n.end = s.pos
+	return n, nil
+}
+
+func (s *sourceScanner) parseArray() (*sourceNode, error) {
+	start := s.pos
+	s.pos++ // consume '['
+	n := &sourceNode{kind: sourceArray, start: start}
+
+	s.skipTrivia()
+	for s.pos < len(s.data) && s.data[s.pos] != ']' {
+		value, err := s.parseValue()
+		if err != nil {
+			return nil, err
+		}
+
+		item := &sourceItem{valStart: value.start, valEnd: value.end, value: value}
+		item.commaEnd = item.valEnd
+		s.skipTrivia()
+		if s.pos < len(s.data) && s.data[s.pos] == ',' {
+			s.pos++
+			item.commaEnd = s.pos
+			s.skipTrivia()
+		}
+		n.items = append(n.items, item)
+	}
+	if s.pos >= len(s.data) {
+		return nil, fmt.Errorf("unexpected end of input in array starting at byte %d", start)
+	}
+	s.pos++ // consume ']'
+	n.end = s.pos
+	return n, nil
+}
+
+func (s *sourceScanner) parseScalar() (*sourceNode, error) {
+	start := s.pos
+	if s.pos < len(s.data) && s.data[s.pos] == '"' {
+		s.pos++
+		for s.pos < len(s.data) {
+			c := s.data[s.pos]
+			if c == '\\' && s.pos+1 < len(s.data) {
+				s.pos += 2
+				continue
+			}
+			s.pos++
+			if c == '"' {
+				return &sourceNode{kind: sourceScalar, start: start, end: s.pos}, nil
+			}
+		}
+		return nil, fmt.Errorf("unterminated string starting at byte %d", start)
+	}
+
+	for s.pos < len(s.data) {
+		c := s.data[s.pos]
+		if c == ',' || c == '}' || c == ']' || c == ' ' || c == '\t' || c == '\n' || c == '\r' {
+			break
+		}
+		if c == '/' && s.pos+1 < len(s.data) && (s.data[s.pos+1] == '/' || s.data[s.pos+1] == '*') {
+			break
+		}
+		s.pos++
+	}
+	if s.pos == start {
+		return nil, fmt.Errorf("expected a value at byte %d", start)
+	}
+	return &sourceNode{kind: sourceScalar, start: start, end: s.pos}, nil
+}
+
+// findEntry は key に対応するエントリ（とそのインデックス）を返します。
+// 見つからない場合は ok が false になります。
+func findEntry(data []byte, n *sourceNode, key string) (idx int, ok bool) {
+	for i, e := range n.entries {
+		var k string
+		if err := json.Unmarshal(data[e.keyStart:e.keyEnd], &k); err != nil {
+			continue
+		}
+		if k == key {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// lineIndent は pos を含む行の、行頭から pos までの空白文字を返します。
+// 行頭から pos までに空白以外の文字があれば ok は false になります
+// （同じ行に他のエントリが並んでいる場合に誤った長いインデントを
+// 生成しないようにするためです）。
+func lineIndent(data []byte, pos int) (indent string, ok bool) {
+	lineStart := pos
+	for lineStart > 0 && data[lineStart-1] != '\n' {
+		lineStart--
+	}
+	for i := lineStart; i < pos; i++ {
+		if data[i] != ' ' && data[i] != '\t' {
+			return "", false
+		}
+	}
+	return string(data[lineStart:pos]), true
+}
+
+// buildNested は未解決の残りトークンから、value を末端に持つ最小限の
+// map/slice を構築します。pointer.Set が存在しない中間パスに対して行う
+// のと同じ規則（次のトークンが数字または "-" なら配列、それ以外は
+// オブジェクト）に従います。挿入先に既存の構造が無い箇所にだけ使うため、
+// 整形済みソースを破壊することはありません。
+func buildNested(tokens []string, value interface{}) interface{} {
+	if len(tokens) == 0 {
+		return value
+	}
+	tok := tokens[0]
+	child := buildNested(tokens[1:], value)
+	if tok == "-" || isDigits(tok) {
+		return []interface{}{child}
+	}
+	return map[string]interface{}{tok: child}
+}
+
+func isDigits(tok string) bool {
+	if tok == "" {
+		return false
+	}
+	for _, r := range tok {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func replaceSpan(data []byte, start, end int, value interface{}) ([]byte, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode value: %w", err)
+	}
+	out := make([]byte, 0, len(data)-(end-start)+len(encoded))
+	out = append(out, data[:start]...)
+	out = append(out, encoded...)
+	out = append(out, data[end:]...)
+	return out, nil
+}
+
+func insertObjectEntry(data []byte, n *sourceNode, key string, tokens []string, value interface{}) ([]byte, error) {
+	innerValue := buildNested(tokens, value)
+	keyBytes, err := json.Marshal(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode key: %w", err)
+	}
+	valBytes, err := json.Marshal(innerValue)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode value: %w", err)
+	}
+
+	if len(n.entries) == 0 {
+		piece := fmt.Sprintf("%s: %s", keyBytes, valBytes)
+		return spliceAt(data, n.start+1, piece), nil
+	}
+
+	last := n.entries[len(n.entries)-1]
+	piece := ""
+	if last.commaEnd == last.valEnd {
+		piece += ","
+	}
+	if indent, ok := lineIndent(data, last.keyStart); ok {
+		piece += "\n" + indent
+	} else {
+		piece += " "
+	}
+	piece += fmt.Sprintf("%s: %s", keyBytes, valBytes)
+	return spliceAt(data, last.commaEnd, piece), nil
+}
+
+func insertArrayItem(data []byte, n *sourceNode, tokens []string, value interface{}) ([]byte, error) {
+	innerValue := buildNested(tokens, value)
+	valBytes, err := json.Marshal(innerValue)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode value: %w", err)
+	}
+
+	if len(n.items) == 0 {
+		return spliceAt(data, n.start+1, string(valBytes)), nil
+	}
+
+	last := n.items[len(n.items)-1]
+	piece := ""
+	if last.commaEnd == last.valEnd {
+		piece += ","
+	}
+	if indent, ok := lineIndent(data, last.valStart); ok {
+		piece += "\n" + indent
+	} else {
+		piece += " "
+	}
+	piece += string(valBytes)
+	return spliceAt(data, last.commaEnd, piece), nil
+}
+
+func spliceAt(data []byte, pos int, text string) []byte {
+	out := make([]byte, 0, len(data)+len(text))
+	out = append(out, data[:pos]...)
+	out = append(out, text...)
+	out = append(out, data[pos:]...)
+	return out
+}
+
+func removeObjectEntry(data []byte, n *sourceNode, idx int) []byte {
+	entry := n.entries[idx]
+	var delStart, delEnd int
+	switch {
+	case entry.commaEnd > entry.valEnd:
+		delStart, delEnd = entry.keyStart, entry.commaEnd
+	case idx > 0:
+		delStart, delEnd = n.entries[idx-1].valEnd, entry.valEnd
+	default:
+		delStart, delEnd = entry.keyStart, entry.valEnd
+	}
+	out := make([]byte, 0, len(data)-(delEnd-delStart))
+	out = append(out, data[:delStart]...)
+	out = append(out, data[delEnd:]...)
+	return out
+}
+
+func removeArrayItem(data []byte, n *sourceNode, idx int) []byte {
+	item := n.items[idx]
+	var delStart, delEnd int
+	switch {
+	case item.commaEnd > item.valEnd:
+		delStart, delEnd = item.valStart, item.commaEnd
+	case idx > 0:
+		delStart, delEnd = n.items[idx-1].valEnd, item.valEnd
+	default:
+		delStart, delEnd = item.valStart, item.valEnd
+	}
+	out := make([]byte, 0, len(data)-(delEnd-delStart))
+	out = append(out, data[:delStart]...)
+	out = append(out, data[delEnd:]...)
+	return out
+}
+
+func resolveArrayIndex(n *sourceNode, tok string) (idx int, appendAt bool, err error) {
+	if tok == "-" {
+		return len(n.items), true, nil
+	}
+	i, convErr := strconv.Atoi(tok)
+	if convErr != nil || i < 0 || i > len(n.items) {
+		return 0, false, fmt.Errorf("invalid array index %q", tok)
+	}
+	if i == len(n.items) {
+		return i, true, nil
+	}
+	return i, false, nil
+}
+
+func applySet(data []byte, n *sourceNode, tokens []string, value interface{}, forceCreate bool) ([]byte, error) {
+	tok, rest := tokens[0], tokens[1:]
+
+	switch n.kind {
+	case sourceObject:
+		idx, ok := findEntry(data, n, tok)
+		if !ok {
+			if !forceCreate {
+				return nil, fmt.Errorf("no such key %q", tok)
+			}
+			return insertObjectEntry(data, n, tok, rest, value)
+		}
+		entry := n.entries[idx]
+		if len(rest) == 0 {
+			return replaceSpan(data, entry.valStart, entry.valEnd, value)
+		}
+		return applySet(data, entry.value, rest, value, forceCreate)
+
+	case sourceArray:
+		idx, appendAt, err := resolveArrayIndex(n, tok)
+		if err != nil {
+			return nil, err
+		}
+		if appendAt {
+			if !forceCreate {
+				return nil, fmt.Errorf("index %q out of range", tok)
+			}
+			return insertArrayItem(data, n, rest, value)
+		}
+		item := n.items[idx]
+		if len(rest) == 0 {
+			return replaceSpan(data, item.valStart, item.valEnd, value)
+		}
+		return applySet(data, item.value, rest, value, forceCreate)
+
+	default:
+		return nil, fmt.Errorf("cannot traverse into %q: not an object or array", tok)
+	}
+}
+
+func applyDelete(data []byte, n *sourceNode, tokens []string) ([]byte, error) {
+	tok, rest := tokens[0], tokens[1:]
+
+	switch n.kind {
+	case sourceObject:
+		idx, ok := findEntry(data, n, tok)
+		if !ok {
+			return nil, fmt.Errorf("no such key %q", tok)
+		}
+		if len(rest) == 0 {
+			return removeObjectEntry(data, n, idx), nil
+		}
+		return applyDelete(data, n.entries[idx].value, rest)
+
+	case sourceArray:
+		i, err := strconv.Atoi(tok)
+		if err != nil || i < 0 || i >= len(n.items) {
+			return nil, fmt.Errorf("index %q out of range", tok)
+		}
+		if len(rest) == 0 {
+			return removeArrayItem(data, n, i), nil
+		}
+		return applyDelete(data, n.items[i].value, rest)
+
+	default:
+		return nil, fmt.Errorf("cannot traverse into %q: not an object or array", tok)
+	}
+}
+
+// SetSourceAtPointer は data（コメントや末尾カンマを含む元のJSONソース）の
+// pointer が指す位置に value を書き込み、更新後のソースを返します。
+// 編集対象の値のバイト範囲だけを書き換えるため、それ以外のコメント・
+// キー順序・配列の改行スタイルはすべてそのまま保持されます。forceCreate
+// が true の場合、存在しない中間のキーや配列要素（末尾への追加）は
+// jsonpointer.Set と同じ規則で新規作成しますが、新規作成される部分は
+// 既存の整形を流用できないため json.Marshal の出力になります。
+func SetSourceAtPointer(data []byte, pointer string, value interface{}, forceCreate bool) ([]byte, error) {
+	tokens, err := jsonpointer.Tokens(pointer)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("cannot set the document root")
+	}
+
+	root, err := parseSourceDoc(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config for editing: %w", err)
+	}
+	return applySet(data, root, tokens, value, forceCreate)
+}
+
+// DeleteSourceAtPointer は data の pointer が指す値を取り除き、更新後の
+// ソースを返します。SetSourceAtPointer と同様、削除対象のエントリ
+// （とその区切りカンマ）以外のバイト列には一切手を加えません。
+func DeleteSourceAtPointer(data []byte, pointer string) ([]byte, error) {
+	tokens, err := jsonpointer.Tokens(pointer)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("cannot delete the document root")
+	}
+
+	root, err := parseSourceDoc(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config for editing: %w", err)
+	}
+	return applyDelete(data, root, tokens)
+}
+
+// SetAtPointer は path の設定ファイルを読み込み、pointer が指す位置に
+// value を書き込んで書き戻します。SetSourceAtPointer 同様、編集対象以外の
+// 書式（コメント・キー順序など）は保持されます。
+func SetAtPointer(path, pointer string, value interface{}, forceCreate bool) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	updated, err := SetSourceAtPointer(data, pointer, value, forceCreate)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, updated, 0644); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
+
+// DeleteAtPointer は path の設定ファイルを読み込み、pointer が指す値を
+// 取り除いて書き戻します。DeleteSourceAtPointer 同様、削除対象以外の
+// 書式は保持されます。
+func DeleteAtPointer(path, pointer string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	updated, err := DeleteSourceAtPointer(data, pointer)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, updated, 0644); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+// jsonparser/sourceedit_test.go
+package jsonparser
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const commentedFixture = `{
+  // used OS list
+  "os": ["ubuntu-latest", "windows-latest", "macos-latest"],
+  "versions": {
+    "go": [
+      "1.23.0",
+      "1.23.1", // pin to fix issue #123
+      "1.23.2",
+    ],
+  },
+  /* GitHub Pages branch */
+  "ghpages_branch": "ghgapes",
+}`
+
+func TestSetSourceAtPointerPreservesFormatting(t *testing.T) {
+	updated, err := SetSourceAtPointer([]byte(commentedFixture), "/versions/go/-", "1.24.0", true)
+	if err != nil {
+		t.Fatalf("SetSourceAtPointer() unexpected error = %v", err)
+	}
+
+	for _, want := range []string{
+		"// used OS list",
+		"// pin to fix issue #123",
+		"/* GitHub Pages branch */",
+		`"os": ["ubuntu-latest", "windows-latest", "macos-latest"]`,
+	} {
+		if !strings.Contains(string(updated), want) {
+			t.Errorf("expected updated source to still contain %q, got:\n%s", want, updated)
+		}
+	}
+
+	var doc interface{}
+	if err := json.Unmarshal(stripJSONComments(updated), &doc); err != nil {
+		t.Fatalf("updated source is not valid JSON once comments are stripped: %v", err)
+	}
+}
+
+func TestSetSourceAtPointerReplacesExistingValue(t *testing.T) {
+	updated, err := SetSourceAtPointer([]byte(commentedFixture), "/ghpages_branch", "gh-pages", false)
+	if err != nil {
+		t.Fatalf("SetSourceAtPointer() unexpected error = %v", err)
+	}
+	if !strings.Contains(string(updated), `"ghpages_branch": "gh-pages"`) {
+		t.Errorf("expected ghpages_branch to be updated, got:\n%s", updated)
+	}
+	if !strings.Contains(string(updated), "/* GitHub Pages branch */") {
+		t.Errorf("expected the comment above ghpages_branch to survive, got:\n%s", updated)
+	}
+}
+
+func TestSetSourceAtPointerWithoutForceCreateRequiresExistingPath(t *testing.T) {
+	if _, err := SetSourceAtPointer([]byte(commentedFixture), "/nope", "value", false); err == nil {
+		t.Error("SetSourceAtPointer() expected an error for a missing key without forceCreate, got nil")
+	}
+}
+
+func TestSetSourceAtPointerCreatesMissingIntermediates(t *testing.T) {
+	updated, err := SetSourceAtPointer([]byte(commentedFixture), "/env/FOO", "bar", true)
+	if err != nil {
+		t.Fatalf("SetSourceAtPointer() unexpected error = %v", err)
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(stripJSONComments(updated), &doc); err != nil {
+		t.Fatalf("updated source is not valid JSON: %v", err)
+	}
+	env, ok := doc["env"].(map[string]interface{})
+	if !ok || env["FOO"] != "bar" {
+		t.Errorf("expected env.FOO to be created as 'bar', got %v", doc["env"])
+	}
+}
+
+func TestDeleteSourceAtPointerPreservesFormatting(t *testing.T) {
+	updated, err := DeleteSourceAtPointer([]byte(commentedFixture), "/os/1")
+	if err != nil {
+		t.Fatalf("DeleteSourceAtPointer() unexpected error = %v", err)
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(stripJSONComments(updated), &doc); err != nil {
+		t.Fatalf("updated source is not valid JSON: %v", err)
+	}
+	os, ok := doc["os"].([]interface{})
+	if !ok || len(os) != 2 || os[0] != "ubuntu-latest" || os[1] != "macos-latest" {
+		t.Errorf("expected os to be [ubuntu-latest macos-latest], got %v", doc["os"])
+	}
+	if !strings.Contains(string(updated), "// used OS list") {
+		t.Errorf("expected the comment above os to survive, got:\n%s", updated)
+	}
+}
+
+func TestDeleteSourceAtPointerLastArrayElement(t *testing.T) {
+	updated, err := DeleteSourceAtPointer([]byte(commentedFixture), "/versions/go/2")
+	if err != nil {
+		t.Fatalf("DeleteSourceAtPointer() unexpected error = %v", err)
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(stripJSONComments(updated), &doc); err != nil {
+		t.Fatalf("updated source is not valid JSON: %v", err)
+	}
+	versions := doc["versions"].(map[string]interface{})
+	goVersions := versions["go"].([]interface{})
+	if len(goVersions) != 2 || goVersions[1] != "1.23.1" {
+		t.Errorf("expected go versions to be [1.23.0 1.23.1], got %v", goVersions)
+	}
+	if !strings.Contains(string(updated), "// pin to fix issue #123") {
+		t.Errorf("expected the inline comment to survive, got:\n%s", updated)
+	}
+}
+
+func TestDeleteSourceAtPointerUnknownKey(t *testing.T) {
+	if _, err := DeleteSourceAtPointer([]byte(commentedFixture), "/nope"); err == nil {
+		t.Error("DeleteSourceAtPointer() expected an error for a missing key, got nil")
+	}
+}
@@ -0,0 +1,48 @@
+// jsonparser/validators.go
+package jsonparser
+
+import (
+	"fmt"
+	"regexp"
+)
+
+var goVersionPattern = regexp.MustCompile(`^[0-9]+(\.[0-9]+){1,2}$`)
+
+// SemverValidator はGoのバージョン表記（例: "1.23.1"）を検証します。
+// RegisterLanguage("go", jsonparser.SemverValidator) として登録します。
+func SemverValidator(versions []string) error {
+	for _, v := range versions {
+		if !goVersionPattern.MatchString(v) {
+			return fmt.Errorf("%q is not a valid semantic version", v)
+		}
+	}
+	return nil
+}
+
+var pep440Pattern = regexp.MustCompile(`^[0-9]+(\.[0-9]+)*((a|b|rc)[0-9]+)?$`)
+
+// PEP440Validator はPython（PEP 440）のバージョン表記（例: "3.12.1"）を
+// 検証します。RegisterLanguage("python", jsonparser.PEP440Validator) として
+// 登録します。
+func PEP440Validator(versions []string) error {
+	for _, v := range versions {
+		if !pep440Pattern.MatchString(v) {
+			return fmt.Errorf("%q is not a valid PEP 440 version", v)
+		}
+	}
+	return nil
+}
+
+var nodeSemverPattern = regexp.MustCompile(`^v[0-9]+\.[0-9]+\.[0-9]+(-[0-9A-Za-z.-]+)?$`)
+
+// NodeSemverValidator はNode.jsの "v" プレフィックス付きSemVer表記
+// （例: "v20.11.0"）を検証します。
+// RegisterLanguage("node", jsonparser.NodeSemverValidator) として登録します。
+func NodeSemverValidator(versions []string) error {
+	for _, v := range versions {
+		if !nodeSemverPattern.MatchString(v) {
+			return fmt.Errorf("%q is not a valid Node.js version (expected a leading 'v')", v)
+		}
+	}
+	return nil
+}
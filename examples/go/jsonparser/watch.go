@@ -0,0 +1,123 @@
+// jsonparser/watch.go
+package jsonparser
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// ConfigListener は Watch が検知した変更を受け取るコールバックです。
+// path は変更が検知された監視対象ファイルの絶対パスで、複数のファイルを
+// Watch している場合にどの変更が発火したのかを区別するために使います。
+// parseErr が nil でない場合、cfg は nil になります。
+type ConfigListener func(path string, cfg *MatrixConfig, parseErr error)
+
+// watchDebounce は連続する書き込みイベントを1回の再パースにまとめるための
+// 待ち時間です。エディタが保存時に複数回ファイルに書き込む場合などに
+// 再パースが重複するのを防ぎます。
+const watchDebounce = 100 * time.Millisecond
+
+var (
+	listenerMu sync.Mutex
+	listeners  = map[string]ConfigListener{}
+)
+
+// AddConfigListener は id をキーとして listener を登録します。同じ id で
+// 再登録した場合は既存の listener を置き換えます。
+func AddConfigListener(id string, listener ConfigListener) {
+	listenerMu.Lock()
+	defer listenerMu.Unlock()
+	listeners[id] = listener
+}
+
+// RemoveConfigListener は id に対応する listener の登録を解除します。
+func RemoveConfigListener(id string) {
+	listenerMu.Lock()
+	defer listenerMu.Unlock()
+	delete(listeners, id)
+}
+
+func notifyListeners(path string, cfg *MatrixConfig, err error) {
+	listenerMu.Lock()
+	snapshot := make([]ConfigListener, 0, len(listeners))
+	for _, l := range listeners {
+		snapshot = append(snapshot, l)
+	}
+	listenerMu.Unlock()
+
+	for _, l := range snapshot {
+		l(path, cfg, err)
+	}
+}
+
+// Watch は path の変更を監視し、変更が起きるたびに ParseConfig で再パースして
+// onChange と、AddConfigListener で登録済みの全リスナーに結果を通知します。
+// 連続する書き込みは watchDebounce の間デバウンスしてから1回だけ再パースします。
+// 返される cancel を呼び出すと監視を停止します。
+func Watch(path string, onChange func(cfg *MatrixConfig, parseErr error)) (cancel func(), err error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create watcher: %w", err)
+	}
+
+	dir := filepath.Dir(path)
+	if err := watcher.Add(dir); err != nil {
+		watcher.Close()
+		return nil, fmt.Errorf("failed to watch %s: %w", dir, err)
+	}
+
+	target, err := filepath.Abs(path)
+	if err != nil {
+		watcher.Close()
+		return nil, fmt.Errorf("failed to resolve absolute path for %s: %w", path, err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		var debounce *time.Timer
+		reload := func() {
+			cfg, err := ParseConfig(path, true)
+			onChange(cfg, err)
+			notifyListeners(target, cfg, err)
+		}
+
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				eventPath, err := filepath.Abs(event.Name)
+				if err != nil || eventPath != target {
+					continue
+				}
+				if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+					continue
+				}
+				if debounce != nil {
+					debounce.Stop()
+				}
+				debounce = time.AfterFunc(watchDebounce, reload)
+			case _, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+			case <-done:
+				if debounce != nil {
+					debounce.Stop()
+				}
+				return
+			}
+		}
+	}()
+
+	cancel = func() {
+		close(done)
+		watcher.Close()
+	}
+	return cancel, nil
+}
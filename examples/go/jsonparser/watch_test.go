@@ -0,0 +1,131 @@
+// jsonparser/watch_test.go
+package jsonparser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeMatrixFixture(t *testing.T, path string, osEntries int) {
+	t.Helper()
+	os_ := `["ubuntu-latest"]`
+	if osEntries == 2 {
+		os_ = `["ubuntu-latest", "windows-latest"]`
+	}
+	content := `{"os": ` + os_ + `, "versions": {"go": ["1.23.0"]}, "ghpages_branch": "gh-pages"}`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "go_project_matrix.json")
+	writeMatrixFixture(t, path, 1)
+
+	changes := make(chan *MatrixConfig, 1)
+	cancel, err := Watch(path, func(cfg *MatrixConfig, err error) {
+		if err == nil {
+			changes <- cfg
+		}
+	})
+	if err != nil {
+		t.Fatalf("Watch() unexpected error = %v", err)
+	}
+	defer cancel()
+
+	writeMatrixFixture(t, path, 2)
+
+	select {
+	case cfg := <-changes:
+		if len(cfg.OS) != 2 {
+			t.Errorf("Expected 2 OS entries after update, got %d", len(cfg.OS))
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for Watch() to report a change")
+	}
+}
+
+func TestConfigListeners(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "go_project_matrix.json")
+	writeMatrixFixture(t, path, 1)
+
+	wantPath, err := filepath.Abs(path)
+	if err != nil {
+		t.Fatalf("failed to resolve fixture path: %v", err)
+	}
+
+	received := make(chan *MatrixConfig, 1)
+	AddConfigListener("watch-test-listener", func(gotPath string, cfg *MatrixConfig, err error) {
+		if err == nil {
+			if gotPath != wantPath {
+				t.Errorf("listener received path %q, want %q", gotPath, wantPath)
+			}
+			received <- cfg
+		}
+	})
+	defer RemoveConfigListener("watch-test-listener")
+
+	cancel, err := Watch(path, func(*MatrixConfig, error) {})
+	if err != nil {
+		t.Fatalf("Watch() unexpected error = %v", err)
+	}
+	defer cancel()
+
+	writeMatrixFixture(t, path, 2)
+
+	select {
+	case cfg := <-received:
+		if len(cfg.OS) != 2 {
+			t.Errorf("Expected 2 OS entries, got %d", len(cfg.OS))
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for listener notification")
+	}
+}
+
+func TestConfigListenersDistinguishPaths(t *testing.T) {
+	dirA, dirB := t.TempDir(), t.TempDir()
+	pathA := filepath.Join(dirA, "go_project_matrix.json")
+	pathB := filepath.Join(dirB, "go_project_matrix.json")
+	writeMatrixFixture(t, pathA, 1)
+	writeMatrixFixture(t, pathB, 1)
+
+	seenPaths := make(chan string, 2)
+	AddConfigListener("watch-test-multi-listener", func(gotPath string, cfg *MatrixConfig, err error) {
+		if err == nil {
+			seenPaths <- gotPath
+		}
+	})
+	defer RemoveConfigListener("watch-test-multi-listener")
+
+	cancelA, err := Watch(pathA, func(*MatrixConfig, error) {})
+	if err != nil {
+		t.Fatalf("Watch(pathA) unexpected error = %v", err)
+	}
+	defer cancelA()
+	cancelB, err := Watch(pathB, func(*MatrixConfig, error) {})
+	if err != nil {
+		t.Fatalf("Watch(pathB) unexpected error = %v", err)
+	}
+	defer cancelB()
+
+	writeMatrixFixture(t, pathB, 2)
+
+	wantPathB, err := filepath.Abs(pathB)
+	if err != nil {
+		t.Fatalf("failed to resolve pathB: %v", err)
+	}
+
+	select {
+	case got := <-seenPaths:
+		if got != wantPathB {
+			t.Errorf("expected the listener to report the changed file %q, got %q", wantPathB, got)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for listener notification")
+	}
+}
@@ -0,0 +1,212 @@
+// jsonpointer/pointer.go
+package jsonpointer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Tokens は RFC 6901 の JSON Pointer 文字列（例: "/versions/go/0"）を
+// トークン列に分解します。ルートを表す空文字列を渡した場合は空のスライスを
+// 返します。
+func Tokens(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid JSON Pointer %q: must start with '/'", pointer)
+	}
+
+	replacer := strings.NewReplacer("~1", "/", "~0", "~")
+	parts := strings.Split(pointer[1:], "/")
+	tokens := make([]string, len(parts))
+	for i, p := range parts {
+		tokens[i] = replacer.Replace(p)
+	}
+	return tokens, nil
+}
+
+// Get は pointer が指す値を doc から取得します。doc は
+// encoding/json がデコードする汎用的な値（map[string]interface{} や
+// []interface{} を含む木構造）を想定しています。
+func Get(doc interface{}, pointer string) (interface{}, error) {
+	tokens, err := Tokens(pointer)
+	if err != nil {
+		return nil, err
+	}
+
+	cur := doc
+	for _, tok := range tokens {
+		next, err := get(cur, tok)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", pointer, err)
+		}
+		cur = next
+	}
+	return cur, nil
+}
+
+func get(cur interface{}, tok string) (interface{}, error) {
+	switch v := cur.(type) {
+	case map[string]interface{}:
+		val, ok := v[tok]
+		if !ok {
+			return nil, fmt.Errorf("no such key %q", tok)
+		}
+		return val, nil
+	case []interface{}:
+		idx, err := strconv.Atoi(tok)
+		if err != nil || idx < 0 || idx >= len(v) {
+			return nil, fmt.Errorf("index %q out of range", tok)
+		}
+		return v[idx], nil
+	default:
+		return nil, fmt.Errorf("cannot traverse into %T at %q", cur, tok)
+	}
+}
+
+// Set は pointer が指す位置に value を書き込み、更新後のドキュメントを
+// 返します。途中のキーや添字が存在しない場合は、オブジェクトまたは配列を
+// 強制的に作成します（次のトークンが数字または "-" なら配列、それ以外は
+// オブジェクトとして作成します）。末尾トークンが "-" の場合は配列の末尾に
+// value を追加します。
+func Set(doc interface{}, pointer string, value interface{}) (interface{}, error) {
+	tokens, err := Tokens(pointer)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return value, nil
+	}
+	return setAt(doc, tokens, value)
+}
+
+func setAt(cur interface{}, tokens []string, value interface{}) (interface{}, error) {
+	tok := tokens[0]
+	rest := tokens[1:]
+
+	if cur == nil {
+		if tok == "-" || isArrayIndex(tok) {
+			cur = []interface{}{}
+		} else {
+			cur = map[string]interface{}{}
+		}
+	}
+
+	switch v := cur.(type) {
+	case map[string]interface{}:
+		if len(rest) == 0 {
+			v[tok] = value
+			return v, nil
+		}
+		child, err := setAt(v[tok], rest, value)
+		if err != nil {
+			return nil, err
+		}
+		v[tok] = child
+		return v, nil
+
+	case []interface{}:
+		idx := len(v)
+		if tok != "-" {
+			n, err := strconv.Atoi(tok)
+			if err != nil || n < 0 || n > len(v) {
+				return nil, fmt.Errorf("invalid array index %q", tok)
+			}
+			idx = n
+		}
+
+		var child interface{}
+		if idx < len(v) {
+			child = v[idx]
+		}
+		if len(rest) == 0 {
+			child = value
+		} else {
+			var err error
+			child, err = setAt(child, rest, value)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if idx == len(v) {
+			return append(v, child), nil
+		}
+		v[idx] = child
+		return v, nil
+
+	default:
+		return nil, fmt.Errorf("cannot set %q on %T", tok, cur)
+	}
+}
+
+func isArrayIndex(tok string) bool {
+	if tok == "" {
+		return false
+	}
+	for _, r := range tok {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Delete は pointer が指す値を doc から取り除き、更新後のドキュメントを
+// 返します。
+func Delete(doc interface{}, pointer string) (interface{}, error) {
+	tokens, err := Tokens(pointer)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("cannot delete the document root")
+	}
+	return deleteAt(doc, tokens)
+}
+
+func deleteAt(cur interface{}, tokens []string) (interface{}, error) {
+	tok := tokens[0]
+	rest := tokens[1:]
+
+	switch v := cur.(type) {
+	case map[string]interface{}:
+		if len(rest) == 0 {
+			if _, ok := v[tok]; !ok {
+				return nil, fmt.Errorf("no such key %q", tok)
+			}
+			delete(v, tok)
+			return v, nil
+		}
+		child, ok := v[tok]
+		if !ok {
+			return nil, fmt.Errorf("no such key %q", tok)
+		}
+		updated, err := deleteAt(child, rest)
+		if err != nil {
+			return nil, err
+		}
+		v[tok] = updated
+		return v, nil
+
+	case []interface{}:
+		idx, err := strconv.Atoi(tok)
+		if err != nil || idx < 0 || idx >= len(v) {
+			return nil, fmt.Errorf("index %q out of range", tok)
+		}
+		if len(rest) == 0 {
+			return append(v[:idx], v[idx+1:]...), nil
+		}
+		updated, err := deleteAt(v[idx], rest)
+		if err != nil {
+			return nil, err
+		}
+		v[idx] = updated
+		return v, nil
+
+	default:
+		return nil, fmt.Errorf("cannot traverse into %T at %q", cur, tok)
+	}
+}
@@ -0,0 +1,108 @@
+// jsonpointer/pointer_test.go
+package jsonpointer
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func sampleDoc(t *testing.T) interface{} {
+	t.Helper()
+	var doc interface{}
+	raw := `{
+		"os": ["ubuntu-latest", "windows-latest"],
+		"versions": {"go": ["1.23.0", "1.23.1"]},
+		"ghpages_branch": "gh-pages"
+	}`
+	if err := json.Unmarshal([]byte(raw), &doc); err != nil {
+		t.Fatalf("failed to build sample doc: %v", err)
+	}
+	return doc
+}
+
+func TestGet(t *testing.T) {
+	doc := sampleDoc(t)
+
+	tests := []struct {
+		name    string
+		pointer string
+		want    interface{}
+		wantErr bool
+	}{
+		{name: "top-level array element", pointer: "/os/1", want: "windows-latest"},
+		{name: "nested map value", pointer: "/versions/go/0", want: "1.23.0"},
+		{name: "string field", pointer: "/ghpages_branch", want: "gh-pages"},
+		{name: "missing key", pointer: "/nope", wantErr: true},
+		{name: "out of range index", pointer: "/os/5", wantErr: true},
+		{name: "pointer without leading slash", pointer: "os", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Get(doc, tt.pointer)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Get() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Get() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetAppendToArray(t *testing.T) {
+	doc := sampleDoc(t)
+
+	updated, err := Set(doc, "/versions/go/-", "1.24.0")
+	if err != nil {
+		t.Fatalf("Set() unexpected error = %v", err)
+	}
+
+	got, err := Get(updated, "/versions/go/2")
+	if err != nil {
+		t.Fatalf("Get() unexpected error = %v", err)
+	}
+	if got != "1.24.0" {
+		t.Errorf("Expected appended value '1.24.0', got %v", got)
+	}
+}
+
+func TestSetCreatesMissingIntermediates(t *testing.T) {
+	doc := sampleDoc(t)
+
+	updated, err := Set(doc, "/env/FOO", "bar")
+	if err != nil {
+		t.Fatalf("Set() unexpected error = %v", err)
+	}
+
+	got, err := Get(updated, "/env/FOO")
+	if err != nil {
+		t.Fatalf("Get() unexpected error = %v", err)
+	}
+	if got != "bar" {
+		t.Errorf("Expected created value 'bar', got %v", got)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	doc := sampleDoc(t)
+
+	updated, err := Delete(doc, "/os/1")
+	if err != nil {
+		t.Fatalf("Delete() unexpected error = %v", err)
+	}
+
+	os, err := Get(updated, "/os")
+	if err != nil {
+		t.Fatalf("Get() unexpected error = %v", err)
+	}
+	want := []interface{}{"ubuntu-latest"}
+	if !reflect.DeepEqual(os, want) {
+		t.Errorf("Expected OS %v after delete, got %v", want, os)
+	}
+
+	if _, err := Delete(doc, ""); err == nil {
+		t.Error("Delete() expected error when deleting document root, got nil")
+	}
+}
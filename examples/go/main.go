@@ -3,28 +3,183 @@ package main
 
 import (
 	"encoding/json"
+	"flag"
 	"fmt"
 	"json2vars-setter-example/jsonparser"
-	"path/filepath"
-	"runtime"
+	"json2vars-setter-example/jsonpointer"
+	"os"
 )
 
+// frmd のサブコマンドは flag.FlagSet で手組みしています。urfave/cli や
+// cobra を新たに依存として追加するより、この程度のサブコマンド数であれば
+// 標準ライブラリのみで十分だと判断したためです。
+
+func usage() string {
+	return `frmd is a small JSON Pointer based editor for GitHub Actions matrix config files.
+
+Usage:
+  frmd get [-config path] <pointer>
+  frmd set [-config path] <pointer> <value>
+  frmd edit [-config path] <pointer> <value>
+  frmd delete [-config path] <pointer>
+
+Flags:
+  -config path   path to the matrix config JSON file
+                 (default: .github/workflows/go_project_matrix.json)
+  -json          decode <value> as a JSON literal instead of a bare string
+                 (affects set/edit only; without it, numeric-looking
+                 values like "3.10" or "1.0" are kept as strings so
+                 version numbers don't get parsed and truncated as
+                 floats)
+
+Pointers follow RFC 6901 (e.g. /versions/go/0). Use the "-" token to
+append to an array, e.g.:
+
+  frmd set /versions/go/- 1.24.0
+  frmd set /versions/python/- 3.10
+  frmd delete /os/1
+`
+}
+
 func main() {
-	_, filename, _, ok := runtime.Caller(0)
-	if !ok {
-		fmt.Println("Error getting current file path")
-		return
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage())
+		os.Exit(1)
+	}
+
+	cmd := os.Args[1]
+	fs := flag.NewFlagSet(cmd, flag.ExitOnError)
+	configFlag := fs.String("config", "", "path to the matrix config JSON file")
+	jsonFlag := fs.Bool("json", false, "decode <value> as a JSON literal instead of a bare string")
+	fs.Parse(os.Args[2:])
+
+	path, err := resolveConfigPath(*configFlag)
+	if err != nil {
+		exitErr(err)
+	}
+
+	args := fs.Args()
+	switch cmd {
+	case "get":
+		err = runGet(path, args)
+	case "set":
+		err = runWrite(path, args, true, *jsonFlag)
+	case "edit":
+		err = runWrite(path, args, false, *jsonFlag)
+	case "delete":
+		err = runDelete(path, args)
+	default:
+		fmt.Fprint(os.Stderr, usage())
+		os.Exit(1)
+	}
+
+	if err != nil {
+		exitErr(err)
+	}
+}
+
+func exitErr(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
+
+// resolveConfigPath は -config で明示されたパスがあればそれを、なければ
+// jsonparser.FindConfigFile で見つかった go_project_matrix.json を返します。
+func resolveConfigPath(configFlag string) (string, error) {
+	if configFlag != "" {
+		return configFlag, nil
+	}
+	return jsonparser.FindConfigFile("go_project_matrix.json")
+}
+
+func runGet(path string, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: frmd get <pointer>")
 	}
 
-	// プロジェクトルートからの相対パスでJSONファイルを指定
-	configPath := filepath.Join(filepath.Dir(filename), "../../.github/workflows/go_project_matrix.json")
+	doc, err := jsonparser.ParseConfigRaw(path)
+	if err != nil {
+		return err
+	}
 
-	config, err := jsonparser.ParseConfig(configPath, false)
+	value, err := jsonpointer.Get(doc, args[0])
 	if err != nil {
-		return
+		return err
+	}
+
+	return printJSON(value)
+}
+
+// runWrite は set と edit の両方を処理します。set（forceCreate=true）は
+// 途中のオブジェクト・配列を自動作成しますが、edit（forceCreate=false）は
+// 既存のパスに対してのみ値を置き換えます。どちらも
+// jsonparser.SetAtPointer でソースを直接編集するため、書き換えた値の
+// バイト範囲以外（コメント、キー順序、配列の改行スタイル）はそのまま
+// 保持されます。
+func runWrite(path string, args []string, forceCreate, jsonMode bool) error {
+	if len(args) != 2 {
+		return fmt.Errorf("usage: frmd set|edit <pointer> <value>")
+	}
+	pointer, rawValue := args[0], args[1]
+	value := parseValue(rawValue, jsonMode)
+
+	if !forceCreate {
+		doc, err := jsonparser.ParseConfigRaw(path)
+		if err != nil {
+			return err
+		}
+		if _, err := jsonpointer.Get(doc, pointer); err != nil {
+			return fmt.Errorf("edit: %s does not exist yet, use set to create it: %w", pointer, err)
+		}
+	}
+
+	if err := jsonparser.SetAtPointer(path, pointer, value, forceCreate); err != nil {
+		return err
+	}
+
+	return printJSON(value)
+}
+
+// runDelete は jsonparser.DeleteAtPointer でソースから直接エントリを
+// 取り除くため、削除対象以外の書式はそのまま保持されます。
+func runDelete(path string, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: frmd delete <pointer>")
+	}
+	pointer := args[0]
+
+	if err := jsonparser.DeleteAtPointer(path, pointer); err != nil {
+		return err
+	}
+
+	fmt.Printf("deleted %s\n", pointer)
+	return nil
+}
+
+// parseValue はCLI引数の文字列をJSON値として解釈します。真偽値・配列・
+// オブジェクト・クォートされた文字列はJSONとしてデコードします。裸の
+// 数値（例: "3.10", "1.0"）は、jsonMode が false の場合はデコードせず
+// 文字列のまま返します。そうしないと "3.10" のようなバージョン番号が
+// float64 の 3.1 に丸められてしまうためです。jsonMode が true の場合は
+// 数値も含めてJSONとしてデコードします。
+func parseValue(raw string, jsonMode bool) interface{} {
+	var v interface{}
+	if err := json.Unmarshal([]byte(raw), &v); err != nil {
+		return raw
+	}
+	if !jsonMode {
+		if _, isNumber := v.(float64); isNumber {
+			return raw
+		}
 	}
+	return v
+}
 
-	// 整形してJSON出力
-	output, _ := json.MarshalIndent(config, "", "  ")
+func printJSON(v interface{}) error {
+	output, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
 	fmt.Println(string(output))
+	return nil
 }
@@ -0,0 +1,161 @@
+// main_test.go
+package main
+
+import (
+	"json2vars-setter-example/jsonparser"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      string
+		jsonMode bool
+		want     interface{}
+	}{
+		{name: "non-numeric string falls back to raw text", raw: "1.24.0", want: "1.24.0"},
+		{name: "two-segment version is kept as a string", raw: "3.10", want: "3.10"},
+		{name: "trailing-zero version is kept as a string", raw: "1.0", want: "1.0"},
+		{name: "quoted string decodes as JSON string", raw: `"1.24.0"`, want: "1.24.0"},
+		{name: "bool decodes as JSON bool", raw: "true", want: true},
+		{name: "array decodes as JSON array", raw: `["a","b"]`, want: []interface{}{"a", "b"}},
+		{name: "number is kept as a string without -json", raw: "42", want: "42"},
+		{name: "number decodes as JSON number with -json", raw: "42", jsonMode: true, want: float64(42)},
+		{name: "version-looking number decodes as JSON number with -json", raw: "3.10", jsonMode: true, want: float64(3.1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseValue(tt.raw, tt.jsonMode); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseValue(%q, %v) = %#v, want %#v", tt.raw, tt.jsonMode, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveConfigPath(t *testing.T) {
+	t.Run("explicit -config flag wins", func(t *testing.T) {
+		got, err := resolveConfigPath("/explicit/path.json")
+		if err != nil {
+			t.Fatalf("resolveConfigPath() unexpected error = %v", err)
+		}
+		if got != "/explicit/path.json" {
+			t.Errorf("resolveConfigPath() = %q, want /explicit/path.json", got)
+		}
+	})
+
+	t.Run("falls back to FindConfigFile", func(t *testing.T) {
+		dir := t.TempDir()
+		configPath := filepath.Join(dir, "go_project_matrix.json")
+		if err := os.WriteFile(configPath, []byte(`{}`), 0644); err != nil {
+			t.Fatalf("failed to write fixture: %v", err)
+		}
+		t.Setenv("JSON2VARS_CONFIG_PATH", dir)
+
+		got, err := resolveConfigPath("")
+		if err != nil {
+			t.Fatalf("resolveConfigPath() unexpected error = %v", err)
+		}
+		want, _ := filepath.Abs(configPath)
+		if got != want {
+			t.Errorf("resolveConfigPath() = %q, want %q", got, want)
+		}
+	})
+}
+
+const cliFixture = `{
+  // used OS list
+  "os": ["ubuntu-latest", "windows-latest", "macos-latest"],
+  "versions": {
+    "go": [
+      "1.23.0",
+      "1.23.1", // pin to fix issue #123
+      "1.23.2",
+    ],
+  },
+  /* GitHub Pages branch */
+  "ghpages_branch": "ghgapes",
+}`
+
+func writeCLIFixture(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "go_project_matrix.json")
+	if err := os.WriteFile(path, []byte(cliFixture), 0644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+	return path
+}
+
+func TestRunWriteSetPreservesFormatting(t *testing.T) {
+	path := writeCLIFixture(t)
+
+	if err := runWrite(path, []string{"/versions/go/-", "1.24.0"}, true, false); err != nil {
+		t.Fatalf("runWrite() unexpected error = %v", err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read config file: %v", err)
+	}
+
+	for _, want := range []string{"// used OS list", "// pin to fix issue #123", "/* GitHub Pages branch */", `"1.24.0"`} {
+		if !strings.Contains(string(got), want) {
+			t.Errorf("expected written config to still contain %q, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestRunWriteSetKeepsTwoSegmentVersionAsString(t *testing.T) {
+	path := writeCLIFixture(t)
+
+	if err := runWrite(path, []string{"/versions/python/-", "3.10"}, true, false); err != nil {
+		t.Fatalf("runWrite() unexpected error = %v", err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read config file: %v", err)
+	}
+	if !strings.Contains(string(got), `"3.10"`) {
+		t.Errorf(`expected written config to contain the quoted string "3.10", got:\n%s`, got)
+	}
+
+	config, err := jsonparser.ParseConfig(path, true)
+	if err != nil {
+		t.Fatalf("config written by runWrite() no longer parses as a MatrixConfig: %v", err)
+	}
+	if got := config.VersionsFor("python"); len(got) != 1 || got[0] != "3.10" {
+		t.Errorf("expected versions.python to be [3.10], got %v", got)
+	}
+}
+
+func TestRunWriteEditRejectsMissingPath(t *testing.T) {
+	path := writeCLIFixture(t)
+
+	if err := runWrite(path, []string{"/nope", "value"}, false, false); err == nil {
+		t.Error("runWrite() expected an error editing a missing pointer, got nil")
+	}
+}
+
+func TestRunDeletePreservesFormatting(t *testing.T) {
+	path := writeCLIFixture(t)
+
+	if err := runDelete(path, []string{"/os/1"}); err != nil {
+		t.Fatalf("runDelete() unexpected error = %v", err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read config file: %v", err)
+	}
+	if strings.Contains(string(got), "windows-latest") {
+		t.Errorf("expected windows-latest to be removed, got:\n%s", got)
+	}
+	if !strings.Contains(string(got), "// used OS list") {
+		t.Errorf("expected the comment above os to survive, got:\n%s", got)
+	}
+}